@@ -0,0 +1,37 @@
+// Command build generates the static site into public/.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/saveside/simple-blog/internal/builder"
+	"github.com/saveside/simple-blog/internal/config"
+)
+
+func main() {
+	force := flag.Bool("force", false, "rebuild every output, ignoring the build manifest")
+	flag.Parse()
+
+	cfg, err := config.Load("config.json")
+	if os.IsNotExist(err) {
+		cfg = config.Default()
+		log.Println("Warning: config.json not found, using default configuration")
+	} else if err != nil {
+		log.Fatalf("loading config.json: %v", err)
+	}
+
+	b, err := builder.New(cfg, *force)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := b.Build(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Build complete! Output in ./public")
+}
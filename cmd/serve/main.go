@@ -0,0 +1,45 @@
+// Command serve runs a dev server over public/: it builds the site once,
+// then watches notes/, templates/, static/, assets/ and config.json,
+// rebuilding and live-reloading connected browsers on any change.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/saveside/simple-blog/internal/builder"
+	"github.com/saveside/simple-blog/internal/config"
+	"github.com/saveside/simple-blog/internal/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to serve on")
+	flag.Parse()
+
+	cfg, err := config.Load("config.json")
+	if os.IsNotExist(err) {
+		cfg = config.Default()
+		log.Println("Warning: config.json not found, using default configuration")
+	} else if err != nil {
+		log.Fatalf("loading config.json: %v", err)
+	}
+
+	b, err := builder.New(cfg, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := b.Build(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	srv := server.New(b)
+	if err := srv.Watch([]string{"notes", "templates", "static", "assets", "config.json"}); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Serving ./public on %s\n", *addr)
+	log.Fatal(srv.ListenAndServe(*addr))
+}
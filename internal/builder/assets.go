@@ -0,0 +1,150 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultFingerprintExclude lists files that must keep a stable name because
+// something outside the build (a CDN, a browser convention) looks them up
+// by a fixed path.
+var defaultFingerprintExclude = map[string]bool{
+	"robots.txt":  true,
+	"_redirects":  true,
+	"favicon.ico": true,
+}
+
+// copyAssets copies static/ and assets/ into public/, fingerprinting
+// filenames with a content hash as it goes and recording the mapping from
+// original to hashed path in b.assetMap.
+func (b *Builder) copyAssets() error {
+	b.assetMap = make(map[string]string)
+
+	exclude := make(map[string]bool, len(defaultFingerprintExclude))
+	for name := range defaultFingerprintExclude {
+		exclude[name] = true
+	}
+	for _, name := range b.cfg.AssetExclude {
+		exclude[name] = true
+	}
+
+	if err := b.copyTreeFingerprinted("static", "static", exclude); err != nil {
+		return err
+	}
+	// It's okay if assets/ doesn't exist.
+	if _, err := os.Stat("assets"); err == nil {
+		if err := b.copyTreeFingerprinted("assets", "assets", exclude); err != nil {
+			return err
+		}
+	}
+
+	return b.writeAssetMap()
+}
+
+// copyTreeFingerprinted copies src into public/<outPrefix>, renaming each
+// file to name.<hash>.ext (except names in exclude) and recording the
+// original -> hashed URL mapping in b.assetMap.
+func (b *Builder) copyTreeFingerprinted(src, outPrefix string, exclude map[string]bool) error {
+	dst := filepath.Join("public", outPrefix)
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+
+		input, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		size := int64(len(input))
+		hash := hashBytes(input)
+
+		originalKey := outPrefix + "/" + rel
+		destName := filepath.Base(rel)
+		if !exclude[destName] {
+			destName = fingerprintedName(destName, hash)
+		}
+		destRel := filepath.Join(filepath.Dir(rel), destName)
+		destPath := filepath.Join(dst, destRel)
+
+		b.assetMap[originalKey] = b.cfg.BaseURL + outPrefix + "/" + filepath.ToSlash(destRel)
+
+		if b.unchanged(originalKey, size, hash) {
+			b.manifest.Inputs[originalKey] = b.prevManifest.Inputs[originalKey]
+			return nil
+		}
+
+		// The fingerprint changes with the content, so any page that
+		// embedded the old URL via {{ asset ... }} needs to re-render
+		// rather than reuse its previous output.
+		b.assetsChanged = true
+
+		// The fingerprint changes with the content, so a prior output under
+		// the old hashed name is now orphaned; remove it before writing the
+		// new one.
+		if rec, ok := b.prevManifest.Inputs[originalKey]; ok {
+			for _, out := range rec.Outputs {
+				if out != destPath {
+					os.Remove(out)
+				}
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(destPath, input, 0644); err != nil {
+			return err
+		}
+
+		b.manifest.Inputs[originalKey] = inputRecord{Size: size, SHA256: hash, Outputs: []string{destPath}}
+		return nil
+	})
+}
+
+// fingerprintedName rewrites name.ext to name.<hash>.ext, using the first 8
+// hex chars of hash (content's SHA-256, already computed by the caller for
+// the build manifest).
+func fingerprintedName(name string, hash string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	return base + "." + hash[:8] + ext
+}
+
+// writeAssetMap writes public/assetmap.json for debugging.
+func (b *Builder) writeAssetMap() error {
+	f, err := os.Create(filepath.Join("public", "assetmap.json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b.assetMap)
+}
+
+// asset resolves the original path of a static/ or assets/ file (e.g.
+// "static/css/style.css") to its fingerprinted URL. Template func: {{ asset
+// "static/css/style.css" }}.
+func (b *Builder) asset(path string) string {
+	if url, ok := b.assetMap[path]; ok {
+		return url
+	}
+	return b.cfg.BaseURL + path
+}
@@ -0,0 +1,162 @@
+// Package builder turns a notes/ and (eventually) posts/ tree into the
+// static site under public/. It is organized as a small pipeline of stages
+// -- content, template, sitemap, feed, search and assets -- that all share
+// the Builder's state instead of re-reading the filesystem themselves.
+package builder
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yuin/goldmark"
+
+	"github.com/saveside/simple-blog/internal/config"
+)
+
+// Builder drives one end-to-end site build.
+type Builder struct {
+	cfg   *config.Config
+	tmpl  *template.Template
+	md    goldmark.Markdown
+	force bool
+
+	cache    *contentCache
+	posts    []Post
+	assetMap map[string]string
+
+	// prevManifest is what the previous build left in public/; manifest is
+	// the one this build produces. globalChanged is true when a dependency
+	// shared by every rendered page (templates, config.json) changed, or
+	// --force was passed, in which case nothing can be skipped. assetsChanged
+	// is the same idea scoped to static/assets: a rendered page can embed
+	// any fingerprinted asset URL via {{ asset ... }}, so when an asset's
+	// fingerprint moves, every page that might reference it has to
+	// re-render rather than keep linking to the now-deleted old filename.
+	prevManifest  *manifest
+	manifest      *manifest
+	globalChanged bool
+	assetsChanged bool
+}
+
+// New prepares a Builder for cfg: it parses the template set up front so
+// template errors surface before any output is written. force disables
+// the incremental build and regenerates every output unconditionally.
+func New(cfg *config.Config, force bool) (*Builder, error) {
+	b := &Builder{
+		cfg:   cfg,
+		md:    newMarkdown(),
+		force: force,
+	}
+
+	tmpl, err := newTemplateSet(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing templates: %w", err)
+	}
+	b.tmpl = tmpl
+
+	return b, nil
+}
+
+// Build regenerates public/, reusing outputs from the previous build
+// wherever their inputs, templates and config.json are unchanged (see
+// build.manifest.json). Pass --force at the command line to bypass this
+// and rebuild everything from scratch.
+func (b *Builder) Build(ctx context.Context) error {
+	b.prevManifest = loadManifest()
+	if b.force {
+		if err := os.RemoveAll("public"); err != nil {
+			return err
+		}
+		b.prevManifest = &manifest{Inputs: map[string]inputRecord{}}
+	}
+	if err := os.MkdirAll("public", 0755); err != nil {
+		return err
+	}
+
+	th, err := templatesHash()
+	if err != nil {
+		return fmt.Errorf("hashing templates: %w", err)
+	}
+	ch, err := hashFile("config.json")
+	if err != nil {
+		return fmt.Errorf("hashing config.json: %w", err)
+	}
+	b.globalChanged = b.force || th != b.prevManifest.TemplatesHash || ch != b.prevManifest.ConfigHash
+	b.assetsChanged = false
+	b.manifest = &manifest{TemplatesHash: th, ConfigHash: ch, Inputs: map[string]inputRecord{}}
+
+	// New only parses templates/ once, but a long-lived Builder (cmd/serve)
+	// calls Build repeatedly on the same instance, so a template edit needs
+	// its own re-parse here rather than just invalidating rendered pages.
+	if th != b.prevManifest.TemplatesHash {
+		tmpl, err := newTemplateSet(b)
+		if err != nil {
+			return fmt.Errorf("parsing templates: %w", err)
+		}
+		b.tmpl = tmpl
+	}
+
+	// Assets must run before anything that executes a template, since
+	// rendered pages may reference fingerprinted URLs via the "asset"
+	// template func.
+	stages := []struct {
+		name string
+		fn   func() error
+	}{
+		{"loading content", b.loadContent},
+		{"copying assets", b.copyAssets},
+		{"rendering posts", b.renderPosts},
+		{"rendering notes", b.renderNotes},
+		{"rendering indexes", b.renderIndexes},
+		{"writing search index", b.writeSearchIndex},
+		{"writing sitemap", b.writeSitemap},
+		{"writing robots.txt", b.writeRobots},
+		{"writing rss feed", b.writeRSS},
+		{"writing atom feed", b.writeAtom},
+		{"writing json feed", b.writeJSONFeed},
+		{"writing 404 page", b.write404},
+		{"writing redirects", b.writeRedirects},
+	}
+
+	for _, stage := range stages {
+		start := time.Now()
+		err := stage.fn()
+		log.Printf("build: %-24s %v", stage.name, time.Since(start))
+		if err != nil {
+			return fmt.Errorf("%s: %w", stage.name, err)
+		}
+	}
+
+	if err := b.removeStaleOutputs(); err != nil {
+		return fmt.Errorf("removing stale outputs: %w", err)
+	}
+	if err := b.manifest.save(); err != nil {
+		return fmt.Errorf("saving build manifest: %w", err)
+	}
+
+	return nil
+}
+
+// allNotesAsPosts returns every cached note as a Post, in the order the
+// notes/ walk found them, for feeds and the sitemap.
+func (b *Builder) allNotesAsPosts() []Post {
+	notes := make([]Post, 0, len(b.cache.notes))
+	for _, n := range b.cache.notes {
+		notes = append(notes, Post{
+			Title:       n.Matter.Title,
+			Description: n.Matter.Description,
+			Date:        n.Matter.Date.Time,
+			Tags:        n.Matter.Tags,
+			Content:     n.HTML,
+			URL:         b.cfg.BaseURL + "notes/" + n.RelPath,
+			Slug:        filepath.Base(n.RelPath),
+			ModTime:     n.ModTime,
+		})
+	}
+	return notes
+}
@@ -0,0 +1,259 @@
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/adrg/frontmatter"
+
+	"github.com/saveside/simple-blog/internal/config"
+)
+
+// PostMatter is the typed frontmatter shape shared by posts and notes. It is
+// decoded from either TOML (delimited by "+++") or YAML (delimited by "---")
+// via github.com/adrg/frontmatter, which picks the format from the opening
+// delimiter.
+type PostMatter struct {
+	Title       string              `yaml:"title" toml:"title"`
+	Description string              `yaml:"description" toml:"description"`
+	Date        FlexDate            `yaml:"date" toml:"date"`
+	Tags        []string            `yaml:"tags" toml:"tags"`
+	Taxonomies  map[string][]string `yaml:"taxonomies" toml:"taxonomies"`
+	Aliases     []string            `yaml:"aliases" toml:"aliases"`
+	Draft       bool                `yaml:"draft" toml:"draft"`
+	Params      map[string]any      `yaml:"params" toml:"params"`
+}
+
+// FlexDate decodes a frontmatter date given either as a bare "2006-01-02"
+// string (the common case) or a full RFC3339 timestamp.
+type FlexDate struct {
+	time.Time
+}
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02"}
+
+func (d *FlexDate) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.parse(s)
+}
+
+// UnmarshalText is what BurntSushi/toml v0.3.1 actually calls for a quoted
+// TOML date string: its indirect() helper checks encoding.TextUnmarshaler,
+// promoted from the embedded time.Time, before any custom toml.Unmarshaler,
+// so defining this method (rather than UnmarshalTOML) is what lets
+// dateLayouts' non-RFC3339 layouts apply here the same as they do for YAML.
+func (d *FlexDate) UnmarshalText(data []byte) error {
+	return d.parse(string(data))
+}
+
+func (d *FlexDate) parse(s string) error {
+	if s == "" {
+		return nil
+	}
+	var err error
+	for _, layout := range dateLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			d.Time = t
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized date format %q", s)
+}
+
+// Post is a rendered post or note, ready for templates.
+type Post struct {
+	Title       string
+	Description string
+	Date        time.Time
+	Tags        []string
+	Content     template.HTML
+	URL         string
+	Slug        string
+
+	// ModTime is the source file's last filesystem modification, used for
+	// the Atom/JSON feed "updated" timestamps (Date is the frontmatter's
+	// first-publication date, which doesn't move on edits).
+	ModTime time.Time
+}
+
+// getPost reads path and decodes its frontmatter into a typed PostMatter
+// once, returning the remaining markdown body and the raw file bytes (used
+// for manifest hashing) alongside it. Every caller that needs a file's
+// metadata goes through this helper instead of re-reading and re-parsing
+// the file itself.
+func getPost(path string) (matter *PostMatter, body []byte, raw []byte, err error) {
+	raw, err = os.ReadFile(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	matter = &PostMatter{}
+	body, err = frontmatter.Parse(bytes.NewReader(raw), matter)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("parsing frontmatter in %s: %w", path, err)
+	}
+
+	return matter, body, raw, nil
+}
+
+// noteEntry is a note discovered during the notes/ walk and parsed (and
+// rendered to HTML) by the worker pool in parallel.go. Every later stage
+// (rendering, tags, search, sitemap, feeds) consumes this cache instead of
+// re-walking notes/, re-parsing frontmatter or re-converting markdown
+// itself.
+type noteEntry struct {
+	Path    string // filesystem path, e.g. "notes/go/slices.md"
+	RelPath string // slash-separated path relative to notes/, without extension
+	Matter  *PostMatter
+	Body    []byte        // raw markdown body
+	HTML    template.HTML // Body converted to HTML, computed once by the worker pool
+	Size    int64         // size of the whole file, for the build manifest
+	SHA256  string        // hash of the whole file, for the build manifest
+	ModTime time.Time     // last filesystem modification, for feed <updated> timestamps
+}
+
+// noteJob is a markdown file discovered during the notes/ walk, queued for
+// the worker pool in parallel.go to parse and render. Node is the tree
+// entry the walk already created with a placeholder title (the file name);
+// the pool patches it in once frontmatter parsing reveals the real title.
+type noteJob struct {
+	Path    string
+	RelPath string
+	Node    *config.NoteNode
+	ModTime time.Time
+}
+
+// assetEntry is a non-markdown file nested under notes/ (images, etc.) that
+// should be copied verbatim alongside the rendered notes.
+type assetEntry struct {
+	Path    string // filesystem path
+	RelPath string // path relative to notes/
+}
+
+// contentCache holds everything the single notes/ walk discovered.
+type contentCache struct {
+	notesTree []*config.NoteNode
+	notes     []*noteEntry
+	assets    []*assetEntry
+}
+
+// loadContent walks notes/ exactly once, building the navigation tree and a
+// flat list of jobs, fans frontmatter parsing and markdown rendering for
+// those jobs out across a worker pool, then reads the homepage content.
+func (b *Builder) loadContent() error {
+	tree, jobs, assets, err := walkNotes("notes", b.cfg.BaseURL)
+	if err != nil {
+		log.Printf("Warning: could not build notes tree: %v", err)
+	}
+
+	notes, failed := b.parseNotesParallel(jobs)
+
+	// A note that fails to parse is still linked from the nav tree built
+	// above; keep whatever it last rendered successfully on disk instead of
+	// letting removeStaleOutputs delete it just because today's edit broke
+	// it.
+	for _, path := range failed {
+		if rec, ok := b.prevManifest.Inputs[path]; ok {
+			b.manifest.Inputs[path] = rec
+		}
+	}
+
+	b.cache = &contentCache{notesTree: tree, notes: notes, assets: assets}
+	b.cfg.NotesTree = tree
+
+	b.cfg.HomeContent = template.HTML("<p>Welcome to my digital garden.</p>")
+	if _, homeBody, _, err := getPost("notes/_index.md"); err == nil {
+		var buf bytes.Buffer
+		if err := b.md.Convert(homeBody, &buf); err == nil {
+			b.cfg.HomeContent = template.HTML(buf.String())
+		}
+	}
+
+	// TODO: wire up a posts/ directory; there is nothing to load yet.
+	b.posts = nil
+
+	return nil
+}
+
+// walkNotes recursively walks root, building both the navigation tree used
+// by the sidebar and a flat list of markdown files queued for parsing. It
+// does no frontmatter parsing or markdown rendering itself -- that's fanned
+// out across a worker pool once the whole tree is known, in
+// parseNotesParallel -- so each tree node gets a placeholder title (the
+// file name) that the pool patches in afterwards.
+func walkNotes(root, baseURL string) ([]*config.NoteNode, []*noteJob, []*assetEntry, error) {
+	var tree []*config.NoteNode
+	var jobs []*noteJob
+	var assets []*assetEntry
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(root, entry.Name())
+		name := entry.Name()
+
+		// Skip hidden files, the images folder, and the special index file.
+		if strings.HasPrefix(name, ".") || name == "images" || name == "_index.md" {
+			continue
+		}
+
+		if entry.IsDir() {
+			children, childJobs, childAssets, err := walkNotes(path, baseURL)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			tree = append(tree, &config.NoteNode{
+				Name:     name,
+				IsDir:    true,
+				Children: children,
+			})
+			jobs = append(jobs, childJobs...)
+			assets = append(assets, childAssets...)
+			continue
+		}
+
+		relPath, _ := filepath.Rel("notes", path)
+		relPath = filepath.ToSlash(relPath)
+
+		if !strings.HasSuffix(name, ".md") {
+			assets = append(assets, &assetEntry{Path: path, RelPath: relPath})
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		urlPath := strings.TrimSuffix(relPath, ".md")
+		node := &config.NoteNode{
+			Name:  name,
+			Title: name, // placeholder until the pool parses frontmatter
+			URL:   baseURL + "notes/" + urlPath,
+			IsDir: false,
+		}
+		tree = append(tree, node)
+
+		jobs = append(jobs, &noteJob{
+			Path:    path,
+			RelPath: strings.TrimSuffix(relPath, ".md"),
+			Node:    node,
+			ModTime: info.ModTime(),
+		})
+	}
+
+	return tree, jobs, assets, nil
+}
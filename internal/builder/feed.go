@@ -0,0 +1,222 @@
+package builder
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// allEntries returns every post and note, newest first -- the shared
+// aggregation every feed format (RSS, Atom, JSON Feed) builds from, so they
+// never drift out of sync with each other.
+func (b *Builder) allEntries() []Post {
+	all := append(append([]Post{}, b.posts...), b.allNotesAsPosts()...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Date.After(all[j].Date)
+	})
+	return all
+}
+
+// writeRSS writes public/rss.xml covering every post and note, newest first.
+func (b *Builder) writeRSS() error {
+	f, err := os.Create(filepath.Join("public", "rss.xml"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	f.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	return b.tmpl.ExecuteTemplate(f, "rss.xml", map[string]any{
+		"Site":      b.cfg,
+		"Posts":     b.allEntries(),
+		"BuildDate": time.Now().Format("Mon, 02 Jan 2006 15:04:05 -0700"),
+	})
+}
+
+// tagAuthority derives the "authority" component of a tag: URI (RFC 4151)
+// from the site's base URL, preferring its hostname and falling back to the
+// raw base URL for relative ones (e.g. "/").
+func tagAuthority(baseURL string) string {
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	return baseURL
+}
+
+// tagURI builds a stable tag: URI (RFC 4151) for an entry: it survives a
+// URL restructuring since it's keyed on the entry's first-publication date
+// and slug rather than its current path.
+func tagURI(authority string, published time.Time, slug string) string {
+	return "tag:" + authority + "," + published.Format("2006-01-02") + ":" + slug
+}
+
+// atomFeed is an Atom 1.0 feed (RFC 4287), built directly with encoding/xml
+// instead of a template so entry IDs, categories and the self link can be
+// assembled precisely.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Links      []atomLink     `xml:"link"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// atomContent holds rendered HTML as escaped character data, which is the
+// simpler of the two forms Atom allows for type="html" (the alternative,
+// an embedded CDATA/XHTML div, needs no escaping but a custom marshaler).
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// writeAtom writes public/atom.xml, an Atom 1.0 feed covering every post and
+// note. Entry IDs are tag: URIs so they stay stable even if a post's URL
+// changes later; <updated> at the feed level reflects the most recently
+// modified entry.
+func (b *Builder) writeAtom() error {
+	entries := b.allEntries()
+	authority := tagAuthority(b.cfg.BaseURL)
+	selfURL := b.cfg.BaseURL + "atom.xml"
+
+	feed := atomFeed{
+		Title: b.cfg.Title,
+		ID:    b.cfg.BaseURL,
+		Links: []atomLink{
+			{Rel: "self", Href: selfURL, Type: "application/atom+xml"},
+			{Rel: "alternate", Href: b.cfg.BaseURL, Type: "text/html"},
+		},
+	}
+
+	var latest time.Time
+	for _, p := range entries {
+		if p.ModTime.After(latest) {
+			latest = p.ModTime
+		}
+
+		categories := make([]atomCategory, 0, len(p.Tags))
+		for _, t := range p.Tags {
+			categories = append(categories, atomCategory{Term: t})
+		}
+
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:      p.Title,
+			ID:         tagURI(authority, p.Date, p.Slug),
+			Published:  p.Date.Format(time.RFC3339),
+			Updated:    entryUpdated(p).Format(time.RFC3339),
+			Links:      []atomLink{{Rel: "alternate", Href: p.URL}},
+			Summary:    p.Description,
+			Categories: categories,
+			Content:    atomContent{Type: "html", Body: string(p.Content)},
+		})
+	}
+	if latest.IsZero() {
+		latest = time.Now()
+	}
+	feed.Updated = latest.Format(time.RFC3339)
+
+	f, err := os.Create(filepath.Join("public", "atom.xml"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	f.WriteString(xml.Header)
+	enc := xml.NewEncoder(f)
+	enc.Indent("", "  ")
+	return enc.Encode(feed)
+}
+
+// entryUpdated returns the timestamp an entry's <updated>/"date_modified"
+// should use: its file's mtime, falling back to the frontmatter date for
+// entries with no recorded mtime.
+func entryUpdated(p Post) time.Time {
+	if p.ModTime.IsZero() {
+		return p.Date
+	}
+	return p.ModTime
+}
+
+// jsonFeed is the JSON Feed 1.1 (https://jsonfeed.org/version/1.1)
+// companion to atom.xml, carrying the same entries.
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentHTML   string   `json:"content_html"`
+	Summary       string   `json:"summary,omitempty"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// writeJSONFeed writes public/feed.json, the JSON Feed 1.1 equivalent of
+// atom.xml, sharing the same entry aggregation and tag: URI IDs.
+func (b *Builder) writeJSONFeed() error {
+	entries := b.allEntries()
+	authority := tagAuthority(b.cfg.BaseURL)
+
+	feed := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       b.cfg.Title,
+		HomePageURL: b.cfg.BaseURL,
+		FeedURL:     b.cfg.BaseURL + "feed.json",
+		Items:       make([]jsonFeedItem, 0, len(entries)),
+	}
+
+	for _, p := range entries {
+		feed.Items = append(feed.Items, jsonFeedItem{
+			ID:            tagURI(authority, p.Date, p.Slug),
+			URL:           p.URL,
+			Title:         p.Title,
+			ContentHTML:   string(p.Content),
+			Summary:       p.Description,
+			DatePublished: p.Date.Format(time.RFC3339),
+			DateModified:  entryUpdated(p).Format(time.RFC3339),
+			Tags:          p.Tags,
+		})
+	}
+
+	f, err := os.Create(filepath.Join("public", "feed.json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(feed)
+}
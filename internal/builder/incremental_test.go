@@ -0,0 +1,159 @@
+package builder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saveside/simple-blog/internal/config"
+)
+
+// writeIncrementalFixture populates dir with the minimal templates, config
+// and notes a Build() needs to run end to end.
+func writeIncrementalFixture(t *testing.T, dir string) {
+	t.Helper()
+
+	mustWrite := func(path, content string) {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mustWrite("config.json", `{"base_url": "/"}`)
+	mustWrite("templates/post.html", `{{ define "post.html" }}{{ .Post.Content }}{{ end }}`)
+	mustWrite("templates/index.html", `{{ define "index.html" }}index{{ end }}`)
+	mustWrite("templates/notes.html", `{{ define "notes.html" }}notes{{ end }}`)
+	mustWrite("templates/tag.html", `{{ define "tag.html" }}{{ .Tag }}{{ end }}`)
+	mustWrite("templates/misc.html", `
+{{ define "sitemap.xml" }}<urlset></urlset>{{ end }}
+{{ define "robots.txt" }}User-agent: *{{ end }}
+{{ define "404.html" }}404{{ end }}
+{{ define "rss.xml" }}<rss></rss>{{ end }}
+`)
+	mustWrite("static/.keep", "")
+	mustWrite("notes/one.md", "---\ntitle: One\ndate: 2024-01-01\n---\nOne\n")
+	mustWrite("notes/two.md", "---\ntitle: Two\ndate: 2024-01-02\n---\nTwo\n")
+}
+
+// chdir switches the working directory to dir for the duration of the test,
+// restoring it on cleanup. Build() works entirely in terms of cwd-relative
+// paths ("notes", "templates", "public", ...), so every Builder test needs
+// this.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(orig); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// TestIncrementalBuild_SkipsUnchangedNotes covers the staleness bugs fixed
+// in this package: an unchanged note's rendered output must be left alone by
+// a rebuild, and a changed note's output must actually be rewritten.
+func TestIncrementalBuild_SkipsUnchangedNotes(t *testing.T) {
+	dir := t.TempDir()
+	writeIncrementalFixture(t, dir)
+	chdir(t, dir)
+
+	b, err := New(config.Default(), false)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := b.Build(context.Background()); err != nil {
+		t.Fatalf("first Build: %v", err)
+	}
+
+	onePath := filepath.Join("public", "notes", "one", "index.html")
+	twoPath := filepath.Join("public", "notes", "two", "index.html")
+
+	twoBefore, err := os.Stat(twoPath)
+	if err != nil {
+		t.Fatalf("stat %s: %v", twoPath, err)
+	}
+
+	// Touch only notes/one.md, then rebuild.
+	if err := os.WriteFile(filepath.Join("notes", "one.md"), []byte("---\ntitle: One\ndate: 2024-01-01\n---\nOne, edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := New(config.Default(), false)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := b2.Build(context.Background()); err != nil {
+		t.Fatalf("second Build: %v", err)
+	}
+
+	oneHTML, err := os.ReadFile(onePath)
+	if err != nil {
+		t.Fatalf("read %s: %v", onePath, err)
+	}
+	if !strings.Contains(string(oneHTML), "edited") {
+		t.Errorf("notes/one.md was edited but %s does not reflect the change: %s", onePath, oneHTML)
+	}
+
+	twoAfter, err := os.Stat(twoPath)
+	if err != nil {
+		t.Fatalf("stat %s: %v", twoPath, err)
+	}
+	if !twoAfter.ModTime().Equal(twoBefore.ModTime()) {
+		t.Errorf("notes/two.md was untouched but %s was rewritten anyway (mtime %v -> %v)", twoPath, twoBefore.ModTime(), twoAfter.ModTime())
+	}
+}
+
+// TestIncrementalBuild_RemovesStaleTagPages covers the other staleness bug
+// fixed in this package: a tag page for a tag no longer used by any
+// post/note must not survive a rebuild.
+func TestIncrementalBuild_RemovesStaleTagPages(t *testing.T) {
+	dir := t.TempDir()
+	writeIncrementalFixture(t, dir)
+	chdir(t, dir)
+
+	if err := os.WriteFile(filepath.Join("notes", "one.md"), []byte("---\ntitle: One\ndate: 2024-01-01\ntags: [golang]\n---\nOne\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := New(config.Default(), false)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := b.Build(context.Background()); err != nil {
+		t.Fatalf("first Build: %v", err)
+	}
+
+	tagPath := filepath.Join("public", "tags", "golang.html")
+	if _, err := os.Stat(tagPath); err != nil {
+		t.Fatalf("stat %s: %v", tagPath, err)
+	}
+
+	if err := os.Remove(filepath.Join("notes", "one.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := New(config.Default(), false)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := b2.Build(context.Background()); err != nil {
+		t.Fatalf("second Build: %v", err)
+	}
+
+	if _, err := os.Stat(tagPath); !os.IsNotExist(err) {
+		t.Errorf("golang's only note was removed but %s still exists (err=%v)", tagPath, err)
+	}
+}
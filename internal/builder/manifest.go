@@ -0,0 +1,138 @@
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// manifestPath is where the build manifest is persisted across runs, inside
+// the output directory so a wiped public/ also means a clean slate.
+const manifestPath = "public/build.manifest.json"
+
+// inputRecord is what the manifest remembers about one input file: enough
+// to tell whether it changed, and which output files it produced so stale
+// outputs can be removed if the input disappears.
+type inputRecord struct {
+	Size    int64    `json:"size"`
+	SHA256  string   `json:"sha256"`
+	Outputs []string `json:"outputs"`
+
+	// HTML is the markdown-converted body for notes, cached so an unchanged
+	// note can skip the conversion itself, not just the disk write. Empty
+	// for non-markdown inputs (assets).
+	HTML string `json:"html,omitempty"`
+}
+
+// manifest is the persisted build.manifest.json. TemplatesHash and
+// ConfigHash are tracked as global dependencies: any change to either
+// invalidates every rendered page, since templates and config.json affect
+// all of them.
+type manifest struct {
+	TemplatesHash string                 `json:"templates_hash"`
+	ConfigHash    string                 `json:"config_hash"`
+	Inputs        map[string]inputRecord `json:"inputs"`
+}
+
+func loadManifest() *manifest {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return &manifest{Inputs: map[string]inputRecord{}}
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return &manifest{Inputs: map[string]inputRecord{}}
+	}
+	if m.Inputs == nil {
+		m.Inputs = map[string]inputRecord{}
+	}
+	return &m
+}
+
+func (m *manifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath, data, 0644)
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashFile hashes a file's contents, returning ("", nil) if it doesn't
+// exist so a missing config.json behaves like an empty/default one instead
+// of an error.
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return hashBytes(data), nil
+}
+
+// templatesHash combines the content of every file in templates/ into one
+// hash, so a change to any template invalidates the whole set.
+func templatesHash() (string, error) {
+	paths, err := filepath.Glob("templates/*")
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return "", err
+		}
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// unchanged reports whether path can reuse its previous build: its content
+// hash must match the prior manifest entry, every output it produced must
+// still exist on disk, and no global dependency (templates, config.json)
+// may have changed.
+func (b *Builder) unchanged(path string, size int64, hash string) bool {
+	if b.force || b.globalChanged {
+		return false
+	}
+
+	rec, ok := b.prevManifest.Inputs[path]
+	if !ok || rec.Size != size || rec.SHA256 != hash {
+		return false
+	}
+	for _, out := range rec.Outputs {
+		if _, err := os.Stat(out); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// removeStaleOutputs deletes the outputs of any input that was tracked by
+// the previous manifest but no longer appears in this run's.
+func (b *Builder) removeStaleOutputs() error {
+	for path, rec := range b.prevManifest.Inputs {
+		if _, ok := b.manifest.Inputs[path]; ok {
+			continue
+		}
+		for _, out := range rec.Outputs {
+			if err := os.Remove(out); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package builder
+
+import (
+	"fmt"
+
+	chroma "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// newMarkdown builds a goldmark instance with the configuration every
+// rendering path uses; each call returns a new, unshared instance.
+func newMarkdown() goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			highlighting.NewHighlighting(
+				highlighting.WithStyle("monokai"),
+				highlighting.WithFormatOptions(
+					chroma.WithLineNumbers(true),
+				),
+			),
+		),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+		),
+		goldmark.WithRendererOptions(
+			html.WithHardWraps(),
+			html.WithXHTML(),
+			renderer.WithNodeRenderers(
+				util.Prioritized(NewHeadingRenderer(), 1000),
+			),
+		),
+	)
+}
+
+// HeadingRenderer renders headings with an auto-generated ID and, for H2/H3,
+// a copy-link button.
+type HeadingRenderer struct {
+	html.Config
+}
+
+func NewHeadingRenderer(opts ...html.Option) renderer.NodeRenderer {
+	r := &HeadingRenderer{
+		Config: html.NewConfig(),
+	}
+	for _, opt := range opts {
+		opt.SetHTMLOption(&r.Config)
+	}
+	return r
+}
+
+func (r *HeadingRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHeading, r.renderHeading)
+}
+
+func (r *HeadingRenderer) renderHeading(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.Heading)
+	if entering {
+		_, _ = w.WriteString("<h")
+		_ = w.WriteByte("0123456"[n.Level])
+		if n.Attributes() != nil {
+			html.RenderAttributes(w, n, html.HeadingAttributeFilter)
+		}
+		_ = w.WriteByte('>')
+	} else {
+		// Get the ID to link to
+		if idAttr, ok := n.Attribute([]byte("id")); ok {
+			var id string
+			switch v := idAttr.(type) {
+			case []byte:
+				id = string(v)
+			case string:
+				id = v
+			}
+
+			// Add copy button only for H2 and H3, and only if ID exists
+			if id != "" && (n.Level == 2 || n.Level == 3) {
+				link := "#" + id
+				btnHTML := fmt.Sprintf(` <button class="copy-link-btn" aria-label="Copy link to this section" onclick="copyToClipboard('%s', this)"><i class="fa-solid fa-link"></i></button>`, link)
+				_, _ = w.WriteString(btnHTML)
+			}
+		}
+
+		_, _ = w.WriteString("</h")
+		_ = w.WriteByte("0123456"[n.Level])
+		_ = w.WriteByte('>')
+	}
+	return ast.WalkContinue, nil
+}
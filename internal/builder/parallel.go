@@ -0,0 +1,116 @@
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"runtime"
+	"sync"
+
+	"github.com/yuin/goldmark"
+)
+
+// parseNotesParallel parses frontmatter for every job concurrently, across a
+// pool of runtime.NumCPU() workers, converting markdown to HTML only for
+// notes that can't reuse the previous build's cached HTML (see parseNote).
+// goldmark.Markdown is not guaranteed goroutine-safe for stateful
+// extensions (e.g. the syntax highlighter), so each worker builds its own
+// instance from newMarkdown rather than sharing one.
+//
+// A job whose frontmatter or markdown fails to parse is logged and left out
+// of the returned notes rather than aborting the whole build -- one bad note
+// shouldn't keep every other note from publishing. Its path is returned
+// separately in failed so the caller can keep its last successful output
+// (rather than deleting a page that's still linked from the nav just
+// because today's edit broke it).
+//
+// Results are written into a slice pre-sized to len(jobs), one index per
+// job, so no mutex is needed to collect them; order is preserved for
+// deterministic output.
+func (b *Builder) parseNotesParallel(jobs []*noteJob) (notes []*noteEntry, failed []string) {
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	entries := make([]*noteEntry, len(jobs))
+	errs := make([]error, len(jobs))
+
+	jobIndexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			md := newMarkdown()
+			for i := range jobIndexes {
+				entries[i], errs[i] = b.parseNote(jobs[i], md)
+			}
+		}()
+	}
+
+	for i := range jobs {
+		jobIndexes <- i
+	}
+	close(jobIndexes)
+	wg.Wait()
+
+	notes = make([]*noteEntry, 0, len(jobs))
+	for i, err := range errs {
+		if err != nil {
+			log.Printf("Warning: skipping note %s: %v", jobs[i].Path, err)
+			failed = append(failed, jobs[i].Path)
+			continue
+		}
+		if entries[i].Matter.Title != "" {
+			jobs[i].Node.Title = entries[i].Matter.Title
+		}
+		notes = append(notes, entries[i])
+	}
+
+	return notes, failed
+}
+
+// parseNote reads and parses a single job using md, the calling worker's own
+// goldmark instance. If the file passes unchanged (same content hash as the
+// previous build's manifest entry, its outputs still on disk, and no
+// template or config change), the cached HTML there is reused instead of
+// re-converting,
+// which is what makes an incremental rebuild skip the expensive part of the
+// pipeline for untouched notes rather than just the disk write.
+func (b *Builder) parseNote(job *noteJob, md goldmark.Markdown) (*noteEntry, error) {
+	matter, body, raw, err := getPost(job.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	size := int64(len(raw))
+	hash := hashBytes(raw)
+
+	var html template.HTML
+	if rec, ok := b.prevManifest.Inputs[job.Path]; ok && rec.HTML != "" && b.unchanged(job.Path, size, hash) {
+		html = template.HTML(rec.HTML)
+	} else {
+		var buf bytes.Buffer
+		if err := md.Convert(body, &buf); err != nil {
+			return nil, fmt.Errorf("rendering markdown in %s: %w", job.Path, err)
+		}
+		html = template.HTML(buf.String())
+	}
+
+	return &noteEntry{
+		Path:    job.Path,
+		RelPath: job.RelPath,
+		Matter:  matter,
+		Body:    body,
+		HTML:    html,
+		Size:    size,
+		SHA256:  hash,
+		ModTime: job.ModTime,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saveside/simple-blog/internal/config"
+)
+
+// benchNoteCount is a few hundred notes -- enough for the worker pool's
+// per-file overhead to be dwarfed by actual markdown conversion.
+const benchNoteCount = 300
+
+const benchNoteBody = `---
+title: Note %d
+date: 2024-01-01
+tags: [bench, note]
+---
+
+# Note %d
+
+Some **markdown** body text with a [link](https://example.com) and a list:
+
+- one
+- two
+- three
+
+` + "```go\nfunc main() {}\n```\n"
+
+// genBenchJobs writes benchNoteCount markdown files into dir and returns
+// the jobs ready to hand to parseNotesParallel/parseNote.
+func genBenchJobs(tb testing.TB, dir string) []*noteJob {
+	tb.Helper()
+
+	jobs := make([]*noteJob, 0, benchNoteCount)
+	for i := 0; i < benchNoteCount; i++ {
+		name := fmt.Sprintf("note-%03d.md", i)
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(fmt.Sprintf(benchNoteBody, i, i)), 0644); err != nil {
+			tb.Fatal(err)
+		}
+		jobs = append(jobs, &noteJob{
+			Path:    path,
+			RelPath: strings.TrimSuffix(name, ".md"),
+			Node:    &config.NoteNode{},
+		})
+	}
+	return jobs
+}
+
+// BenchmarkParseNotesParallel measures the worker pool against
+// BenchmarkParseNotesSerial on the same corpus; run with:
+//
+//	go test ./internal/builder -bench . -run '^$'
+func BenchmarkParseNotesParallel(b *testing.B) {
+	jobs := genBenchJobs(b, b.TempDir())
+	bd := &Builder{prevManifest: &manifest{Inputs: map[string]inputRecord{}}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bd.parseNotesParallel(jobs)
+	}
+}
+
+// BenchmarkParseNotesSerial is the single-goroutine baseline: the same
+// parseNote call, on one shared markdown instance, with no fan-out.
+func BenchmarkParseNotesSerial(b *testing.B) {
+	jobs := genBenchJobs(b, b.TempDir())
+	bd := &Builder{prevManifest: &manifest{Inputs: map[string]inputRecord{}}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		md := newMarkdown()
+		for _, job := range jobs {
+			if _, err := bd.parseNote(job, md); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
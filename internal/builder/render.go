@@ -0,0 +1,237 @@
+package builder
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// renderPosts renders everything under posts/ into public/<slug>/index.html.
+func (b *Builder) renderPosts() error {
+	// TODO: wire up a posts/ directory; there is nothing to render yet.
+	var postFiles []string
+
+	for _, path := range postFiles {
+		slug := strings.TrimSuffix(filepath.Base(path), ".md")
+		post, err := b.renderMarkdown(path, slug, slug)
+		if err != nil {
+			continue
+		}
+		b.posts = append(b.posts, post)
+
+		outPath := filepath.Join("public", slug, "index.html")
+		if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(outPath)
+		if err != nil {
+			return err
+		}
+		err = b.tmpl.ExecuteTemplate(f, "post.html", map[string]any{
+			"Site": b.cfg,
+			"Post": post,
+		})
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(b.posts, func(i, j int) bool {
+		return b.posts[i].Date.After(b.posts[j].Date)
+	})
+
+	return nil
+}
+
+// renderMarkdown reads and converts a single markdown file into a Post.
+func (b *Builder) renderMarkdown(path, slug, outputRelPath string) (Post, error) {
+	matter, body, _, err := getPost(path)
+	if err != nil {
+		return Post{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := b.md.Convert(body, &buf); err != nil {
+		return Post{}, err
+	}
+
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	return Post{
+		Title:       matter.Title,
+		Description: matter.Description,
+		Date:        matter.Date.Time,
+		Tags:        matter.Tags,
+		Content:     template.HTML(buf.String()),
+		Slug:        slug,
+		URL:         b.cfg.BaseURL + outputRelPath,
+		ModTime:     modTime,
+	}, nil
+}
+
+// renderNotes renders every cached note to public/notes/<relpath>/index.html
+// and copies every non-markdown file discovered alongside them.
+func (b *Builder) renderNotes() error {
+	for _, n := range b.cache.notes {
+		outPath := filepath.Join("public", "notes", n.RelPath, "index.html")
+
+		// A note's own content may be unchanged, but its rendered page can
+		// still embed a fingerprinted asset URL via {{ asset ... }}; if any
+		// asset's fingerprint moved this build, every note needs to
+		// re-render rather than keep linking to a now-deleted filename.
+		if !b.assetsChanged && b.unchanged(n.Path, n.Size, n.SHA256) {
+			b.manifest.Inputs[n.Path] = b.prevManifest.Inputs[n.Path]
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+			return err
+		}
+
+		post := Post{
+			Title:       n.Matter.Title,
+			Description: n.Matter.Description,
+			Date:        n.Matter.Date.Time,
+			Tags:        n.Matter.Tags,
+			Content:     n.HTML,
+			Slug:        filepath.Base(n.RelPath),
+			URL:         b.cfg.BaseURL + "notes/" + n.RelPath,
+			ModTime:     n.ModTime,
+		}
+
+		f, err := os.Create(outPath)
+		if err != nil {
+			return err
+		}
+		// Reuse post template for now, or make a specific note template.
+		err = b.tmpl.ExecuteTemplate(f, "post.html", map[string]any{
+			"Site": b.cfg,
+			"Post": post,
+		})
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		b.manifest.Inputs[n.Path] = inputRecord{Size: n.Size, SHA256: n.SHA256, Outputs: []string{outPath}, HTML: string(n.HTML)}
+	}
+
+	for _, a := range b.cache.assets {
+		destPath := filepath.Join("public", "notes", a.RelPath)
+		input, err := os.ReadFile(a.Path)
+		if err != nil {
+			return err
+		}
+		size := int64(len(input))
+		hash := hashBytes(input)
+
+		if b.unchanged(a.Path, size, hash) {
+			b.manifest.Inputs[a.Path] = b.prevManifest.Inputs[a.Path]
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(destPath, input, 0644); err != nil {
+			return err
+		}
+		b.manifest.Inputs[a.Path] = inputRecord{Size: size, SHA256: hash, Outputs: []string{destPath}}
+	}
+
+	return nil
+}
+
+// renderIndexes writes index.html, notes.html and the per-tag pages.
+func (b *Builder) renderIndexes() error {
+	f, err := os.Create(filepath.Join("public", "index.html"))
+	if err != nil {
+		return err
+	}
+	err = b.tmpl.ExecuteTemplate(f, "index.html", map[string]any{
+		"Site":  b.cfg,
+		"Posts": b.posts,
+	})
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	f, err = os.Create(filepath.Join("public", "notes.html"))
+	if err != nil {
+		return err
+	}
+	err = b.tmpl.ExecuteTemplate(f, "notes.html", map[string]any{
+		"Site": b.cfg,
+	})
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	return b.renderTagPages()
+}
+
+// renderTagPages groups posts and notes by tag and writes public/tags/*.html.
+func (b *Builder) renderTagPages() error {
+	tagsMap := make(map[string][]Post)
+
+	for _, p := range b.posts {
+		for _, t := range p.Tags {
+			tagsMap[strings.ToLower(t)] = append(tagsMap[strings.ToLower(t)], p)
+		}
+	}
+
+	for _, n := range b.cache.notes {
+		p := Post{
+			Title:       n.Matter.Title,
+			Description: n.Matter.Description,
+			Date:        n.Matter.Date.Time,
+			URL:         b.cfg.BaseURL + "notes/" + n.RelPath,
+		}
+		for _, t := range n.Matter.Tags {
+			tagsMap[strings.ToLower(t)] = append(tagsMap[strings.ToLower(t)], p)
+		}
+	}
+
+	// Tag pages aren't tracked in the manifest (there's no single input they
+	// depend on), so removeStaleOutputs can't clean up a tag whose last
+	// post/note was removed; regenerating the directory from scratch every
+	// build is what keeps it in sync instead.
+	if err := os.RemoveAll("public/tags"); err != nil {
+		return err
+	}
+	if err := os.MkdirAll("public/tags", 0755); err != nil {
+		return err
+	}
+
+	for tag, taggedPosts := range tagsMap {
+		sort.Slice(taggedPosts, func(i, j int) bool {
+			return taggedPosts[i].Date.After(taggedPosts[j].Date)
+		})
+
+		f, err := os.Create(filepath.Join("public", "tags", tag+".html"))
+		if err != nil {
+			continue
+		}
+		err = b.tmpl.ExecuteTemplate(f, "tag.html", map[string]any{
+			"Site":  b.cfg,
+			"Tag":   tag,
+			"Posts": taggedPosts,
+		})
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
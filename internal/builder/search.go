@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// writeSearchIndex writes public/search.json, the flat index the client-side
+// search box loads.
+func (b *Builder) writeSearchIndex() error {
+	var items []map[string]string
+
+	for _, p := range b.posts {
+		items = append(items, map[string]string{
+			"title":   p.Title,
+			"url":     p.URL,
+			"date":    p.Date.Format("Jan 02, 2006"),
+			"content": string(p.Content),
+			"type":    "post",
+			"tags":    strings.Join(p.Tags, ","),
+		})
+	}
+
+	for _, n := range b.cache.notes {
+		title := n.Matter.Title
+		if title == "" {
+			title = filepath.Base(n.RelPath)
+		}
+
+		formattedDate := ""
+		if !n.Matter.Date.IsZero() {
+			formattedDate = n.Matter.Date.Format("Jan 02, 2006")
+		}
+
+		items = append(items, map[string]string{
+			"title":   title,
+			"url":     b.cfg.BaseURL + "notes/" + n.RelPath,
+			"date":    formattedDate,
+			"content": string(n.HTML),
+			"type":    "note",
+			"tags":    strings.Join(n.Matter.Tags, ","),
+		})
+	}
+
+	f, err := os.Create(filepath.Join("public", "search.json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(items)
+}
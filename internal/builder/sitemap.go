@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// writeSitemap writes public/sitemap.xml covering every post and note.
+func (b *Builder) writeSitemap() error {
+	f, err := os.Create(filepath.Join("public", "sitemap.xml"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	f.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	return b.tmpl.ExecuteTemplate(f, "sitemap.xml", map[string]any{
+		"Site":  b.cfg,
+		"Posts": b.posts,
+		"Notes": b.allNotesAsPosts(),
+	})
+}
+
+// writeRobots writes public/robots.txt.
+func (b *Builder) writeRobots() error {
+	f, err := os.Create(filepath.Join("public", "robots.txt"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return b.tmpl.ExecuteTemplate(f, "robots.txt", map[string]any{
+		"Site": b.cfg,
+	})
+}
+
+// write404 writes public/404.html.
+func (b *Builder) write404() error {
+	f, err := os.Create(filepath.Join("public", "404.html"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return b.tmpl.ExecuteTemplate(f, "404.html", map[string]any{
+		"Site": b.cfg,
+	})
+}
+
+// writeRedirects writes public/_redirects for Netlify/Cloudflare Pages.
+func (b *Builder) writeRedirects() error {
+	f, err := os.Create(filepath.Join("public", "_redirects"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString("/* /404.html 404\n")
+	return err
+}
@@ -0,0 +1,17 @@
+package builder
+
+import (
+	"html/template"
+	"strings"
+)
+
+// newTemplateSet parses every file in templates/ into a single named
+// template set, registering the funcs available to all of them. b.asset is
+// bound now but only resolves real paths once the assets stage has run.
+func newTemplateSet(b *Builder) (*template.Template, error) {
+	funcMap := template.FuncMap{
+		"lower": strings.ToLower,
+		"asset": b.asset,
+	}
+	return template.New("").Funcs(funcMap).ParseGlob("templates/*")
+}
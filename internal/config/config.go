@@ -0,0 +1,62 @@
+// Package config holds the site-wide configuration loaded from
+// config.json and the data the content stage attaches to it before
+// templates are rendered.
+package config
+
+import (
+	"encoding/json"
+	"html/template"
+	"os"
+)
+
+// Config is the top-level site configuration, loaded from config.json and
+// passed to every template as "Site".
+type Config struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	UmamiID     string `json:"umami_id"`
+	UmamiURL    string `json:"umami_url"`
+	BaseURL     string `json:"base_url"`
+
+	// AssetExclude lists filenames (matched by base name) that must keep a
+	// stable name instead of being content-hash fingerprinted, on top of
+	// the builder's own defaults (robots.txt, _redirects, favicon.ico).
+	AssetExclude []string `json:"asset_exclude"`
+
+	// HomeContent and NotesTree are populated by the content stage, not
+	// decoded from config.json.
+	HomeContent template.HTML `json:"-"`
+	NotesTree   []*NoteNode   `json:"-"`
+}
+
+// NoteNode represents a file or folder in the notes tree.
+type NoteNode struct {
+	Name     string
+	URL      string
+	IsDir    bool
+	Children []*NoteNode
+	Title    string
+}
+
+// Default returns the configuration used when config.json is absent.
+func Default() *Config {
+	return &Config{
+		Title:       "Minimal Go Blog",
+		Description: "A static blog generated with Go.",
+		BaseURL:     "/",
+	}
+}
+
+// Load reads and decodes config.json from path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
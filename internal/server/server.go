@@ -0,0 +1,139 @@
+// Package server runs the dev-time HTTP server: it serves public/ with
+// correct MIME types and a 404 fallback, injects a live-reload script into
+// HTML responses, and pushes reload events over SSE when the watched
+// source directories change.
+package server
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/saveside/simple-blog/internal/builder"
+)
+
+// liveReloadScript is spliced in before </body> of every HTML response. It
+// listens on /_events and reloads the page on any "reload" message.
+const liveReloadScript = `<script>
+(function() {
+	var es = new EventSource("/_events");
+	es.onmessage = function(e) {
+		if (e.data === "reload") location.reload();
+	};
+})();
+</script>
+</body>`
+
+// Server serves dir (normally "public") over HTTP and rebuilds b whenever a
+// watched source directory changes, notifying connected browsers over SSE.
+type Server struct {
+	b   *builder.Builder
+	dir string
+
+	mu      sync.Mutex
+	clients map[chan string]bool
+}
+
+// New prepares a Server around an already-built Builder.
+func New(b *builder.Builder) *Server {
+	return &Server{
+		b:       b,
+		dir:     "public",
+		clients: make(map[chan string]bool),
+	}
+}
+
+// ListenAndServe starts the HTTP server on addr and blocks until it exits.
+func (s *Server) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_events", s.handleEvents)
+	mux.HandleFunc("/", s.handleStatic)
+
+	return http.ListenAndServe(addr, mux)
+}
+
+// handleStatic serves a file from s.dir, falling back to dir/404.html when
+// it doesn't exist, and injects the live-reload script into HTML.
+func (s *Server) handleStatic(w http.ResponseWriter, r *http.Request) {
+	path := filepath.Join(s.dir, filepath.Clean(r.URL.Path))
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "index.html")
+	}
+
+	status := http.StatusOK
+	data, err := os.ReadFile(path)
+	if err != nil {
+		path = filepath.Join(s.dir, "404.html")
+		data, err = os.ReadFile(path)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		status = http.StatusNotFound
+	}
+
+	if strings.HasSuffix(path, ".html") {
+		data = bytes.Replace(data, []byte("</body>"), []byte(liveReloadScript), 1)
+	}
+
+	ctype := mime.TypeByExtension(filepath.Ext(path))
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", ctype)
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// handleEvents is the /_events SSE endpoint the injected script connects to.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ch := make(chan string, 1)
+	s.mu.Lock()
+	s.clients[ch] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case msg := <-ch:
+			if _, err := w.Write([]byte("data: " + msg + "\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// broadcastReload tells every connected browser to reload.
+func (s *Server) broadcastReload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		select {
+		case ch <- "reload":
+		default: // client already has a reload pending
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// rebuildDebounce is how long Watch waits after the last filesystem event
+// before rebuilding, so a burst of writes (a save-all, a git checkout)
+// causes one rebuild instead of one per file.
+const rebuildDebounce = 200 * time.Millisecond
+
+// Watch adds every path in roots to an fsnotify watcher (recursing into
+// directories) and rebuilds s.b on any change, pushing a reload event to
+// connected browsers afterwards. It returns once watching has started; the
+// watch loop itself runs in a background goroutine for the life of the
+// process.
+func (s *Server) Watch(roots []string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	for _, root := range roots {
+		if err := addRecursive(watcher, root); err != nil {
+			log.Printf("Warning: could not watch %s: %v", root, err)
+		}
+	}
+
+	go s.watchLoop(watcher)
+	return nil
+}
+
+// addRecursive adds path to watcher, and every subdirectory if path is a
+// directory. A missing path (e.g. an absent assets/) is not an error.
+func addRecursive(watcher *fsnotify.Watcher, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return watcher.Add(path)
+	}
+
+	return filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return watcher.Add(p)
+		}
+		return nil
+	})
+}
+
+// watchLoop debounces fsnotify events into rebuilds until watcher is
+// closed.
+func (s *Server) watchLoop(watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	var timer *time.Timer
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(rebuildDebounce, s.rebuild)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("watch error: %v", err)
+		}
+	}
+}
+
+// rebuild runs an incremental build and, on success, reloads every
+// connected browser.
+func (s *Server) rebuild() {
+	if err := s.b.Build(context.Background()); err != nil {
+		log.Printf("rebuild failed: %v", err)
+		return
+	}
+	s.broadcastReload()
+}